@@ -0,0 +1,71 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/books"
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/database"
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/middleware/accesslog"
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/users"
+	"github.com/gin-gonic/gin"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func main() {
+	db, err := database.Connect("app.db")
+	if err != nil {
+		log.Fatalf("failed to connect to the database: %v", err)
+	}
+
+	objects, err := newObjectStore()
+	if err != nil {
+		log.Fatalf("failed to connect to the object store: %v", err)
+	}
+
+	secret := os.Getenv("JWT_SECRET")
+
+	bookHandler := books.NewHandler(
+		books.NewGormBookRepository(db),
+		books.NewGormAttachmentRepository(db),
+		objects,
+	)
+	userHandler := users.NewHandler(users.NewGormUserRepository(db), secret)
+
+	r := gin.New()
+	r.Use(gin.Recovery())
+	users.RegisterRoutes(r, userHandler)
+
+	authorized := r.Group("/")
+	authorized.Use(accesslog.New(accesslog.Config{
+		JSON: os.Getenv("ACCESS_LOG_JSON") == "true",
+		RemoteUser: func(c *gin.Context) string {
+			userID, ok := users.CurrentUserID(c)
+			if !ok {
+				return "-"
+			}
+			return strconv.FormatUint(uint64(userID), 10)
+		},
+	}))
+	authorized.Use(users.AuthMiddleware(secret))
+	books.RegisterRoutes(authorized, bookHandler)
+
+	if err := r.Run(); err != nil {
+		log.Fatalf("failed to start server: %v", err)
+	}
+}
+
+// newObjectStore builds the MinIO-backed ObjectStore from MINIO_* env vars.
+func newObjectStore() (books.ObjectStore, error) {
+	client, err := minio.New(os.Getenv("MINIO_ENDPOINT"), &minio.Options{
+		Creds:  credentials.NewStaticV4(os.Getenv("MINIO_ACCESS_KEY"), os.Getenv("MINIO_SECRET_KEY"), ""),
+		Secure: os.Getenv("MINIO_USE_SSL") == "true",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return books.NewMinioObjectStore(client, os.Getenv("MINIO_BUCKET")), nil
+}
@@ -0,0 +1,12 @@
+package books
+
+import "github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+
+// AttachmentRepository persists attachment metadata (object key, filename,
+// content type, size) for books; the file bytes themselves live in an
+// ObjectStore.
+type AttachmentRepository interface {
+	Create(att *models.Attachment) error
+	ListByBook(bookID uint) ([]models.Attachment, error)
+	DeleteByBook(bookID uint) error
+}
@@ -0,0 +1,35 @@
+package books
+
+import (
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+	"gorm.io/gorm"
+)
+
+// gormAttachmentRepository is the production AttachmentRepository, backed
+// by GORM.
+type gormAttachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAttachmentRepository builds an AttachmentRepository backed by the
+// given GORM connection. The caller is responsible for migrating
+// models.Attachment.
+func NewGormAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &gormAttachmentRepository{db: db}
+}
+
+func (r *gormAttachmentRepository) Create(att *models.Attachment) error {
+	return r.db.Create(att).Error
+}
+
+func (r *gormAttachmentRepository) ListByBook(bookID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	if err := r.db.Where("book_id = ?", bookID).Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *gormAttachmentRepository) DeleteByBook(bookID uint) error {
+	return r.db.Where("book_id = ?", bookID).Delete(&models.Attachment{}).Error
+}
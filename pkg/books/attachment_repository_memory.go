@@ -0,0 +1,57 @@
+package books
+
+import (
+	"sync"
+
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+)
+
+// memoryAttachmentRepository is an AttachmentRepository backed by a plain
+// slice, intended for tests and local development.
+type memoryAttachmentRepository struct {
+	mu          sync.Mutex
+	attachments []models.Attachment
+	nextID      uint
+}
+
+// NewMemoryAttachmentRepository builds an in-memory AttachmentRepository.
+func NewMemoryAttachmentRepository() AttachmentRepository {
+	return &memoryAttachmentRepository{nextID: 1}
+}
+
+func (r *memoryAttachmentRepository) Create(att *models.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	att.ID = r.nextID
+	r.nextID++
+	r.attachments = append(r.attachments, *att)
+	return nil
+}
+
+func (r *memoryAttachmentRepository) ListByBook(bookID uint) ([]models.Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matched []models.Attachment
+	for _, att := range r.attachments {
+		if att.BookID == bookID {
+			matched = append(matched, att)
+		}
+	}
+	return matched, nil
+}
+
+func (r *memoryAttachmentRepository) DeleteByBook(bookID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	remaining := r.attachments[:0]
+	for _, att := range r.attachments {
+		if att.BookID != bookID {
+			remaining = append(remaining, att)
+		}
+	}
+	r.attachments = remaining
+	return nil
+}
@@ -0,0 +1,159 @@
+package books
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeObjectStore is an in-memory ObjectStore used to assert upload,
+// download, and cleanup behavior without touching the filesystem or MinIO.
+type fakeObjectStore struct {
+	objects map[string][]byte
+}
+
+func newFakeObjectStore() *fakeObjectStore {
+	return &fakeObjectStore{objects: make(map[string][]byte)}
+}
+
+func (s *fakeObjectStore) Put(key string, r io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.objects[key] = data
+	return nil
+}
+
+func (s *fakeObjectStore) Get(key string) (io.ReadCloser, error) {
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *fakeObjectStore) Delete(key string) error {
+	delete(s.objects, key)
+	return nil
+}
+
+func multipartFileRequest(t *testing.T, url, filename, contentType string, content []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreatePart(map[string][]string{
+		"Content-Disposition": {fmt.Sprintf(`form-data; name="file"; filename="%s"`, filename)},
+		"Content-Type":        {contentType},
+	})
+	if err != nil {
+		t.Fatalf("failed to create multipart part: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatalf("failed to write multipart content: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close multipart writer: %v", err)
+	}
+
+	req, _ := http.NewRequest("POST", url, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestUploadAndFetchCover(t *testing.T) {
+	r := gin.Default()
+	store := newFakeObjectStore()
+	h := NewHandler(NewMemoryBookRepository(), NewMemoryAttachmentRepository(), store)
+
+	book := models.Book{Title: "Test Book", Author: "Test Author"}
+	h.Repo.Create(&book)
+
+	RegisterRoutes(r, h)
+
+	cover := []byte("fake-jpeg-bytes")
+	req := multipartFileRequest(t, fmt.Sprintf("/books/%d/cover", book.ID), "cover.jpg", "image/jpeg", cover)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var updated models.Book
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.NotEmpty(t, updated.CoverImageKey)
+	assert.Equal(t, "image/jpeg", updated.CoverImageType)
+
+	getReq, _ := http.NewRequest("GET", fmt.Sprintf("/books/%d/cover", book.ID), nil)
+	getW := httptest.NewRecorder()
+	r.ServeHTTP(getW, getReq)
+	assert.Equal(t, http.StatusOK, getW.Code)
+	assert.Equal(t, "image/jpeg", getW.Header().Get("Content-Type"))
+	assert.Equal(t, cover, getW.Body.Bytes())
+}
+
+func TestUploadAttachment(t *testing.T) {
+	r := gin.Default()
+	store := newFakeObjectStore()
+	h := NewHandler(NewMemoryBookRepository(), NewMemoryAttachmentRepository(), store)
+
+	book := models.Book{Title: "Test Book", Author: "Test Author"}
+	h.Repo.Create(&book)
+
+	RegisterRoutes(r, h)
+
+	content := []byte("sample attachment contents")
+	req := multipartFileRequest(t, fmt.Sprintf("/books/%d/attachments", book.ID), "notes.txt", "text/plain", content)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var attachment models.Attachment
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &attachment))
+	assert.Equal(t, book.ID, attachment.BookID)
+	assert.Equal(t, "notes.txt", attachment.Filename)
+
+	stored, err := store.Get(attachment.Key)
+	assert.Nil(t, err)
+	storedBytes, err := io.ReadAll(stored)
+	assert.Nil(t, err)
+	assert.Equal(t, content, storedBytes)
+}
+
+func TestDeleteBookCleansUpObjects(t *testing.T) {
+	r := gin.Default()
+	store := newFakeObjectStore()
+	h := NewHandler(NewMemoryBookRepository(), NewMemoryAttachmentRepository(), store)
+
+	book := models.Book{Title: "Test Book", Author: "Test Author"}
+	h.Repo.Create(&book)
+
+	RegisterRoutes(r, h)
+
+	coverReq := multipartFileRequest(t, fmt.Sprintf("/books/%d/cover", book.ID), "cover.jpg", "image/jpeg", []byte("cover"))
+	r.ServeHTTP(httptest.NewRecorder(), coverReq)
+
+	attachmentReq := multipartFileRequest(t, fmt.Sprintf("/books/%d/attachments", book.ID), "notes.txt", "text/plain", []byte("notes"))
+	attachmentW := httptest.NewRecorder()
+	r.ServeHTTP(attachmentW, attachmentReq)
+	var attachment models.Attachment
+	assert.Nil(t, json.Unmarshal(attachmentW.Body.Bytes(), &attachment))
+
+	deleteReq, _ := http.NewRequest("DELETE", fmt.Sprintf("/books/%d", book.ID), nil)
+	deleteW := httptest.NewRecorder()
+	r.ServeHTTP(deleteW, deleteReq)
+	assert.Equal(t, http.StatusOK, deleteW.Code)
+
+	assert.Empty(t, store.objects)
+
+	remaining, err := h.Attachments.ListByBook(book.ID)
+	assert.Nil(t, err)
+	assert.Empty(t, remaining)
+}
@@ -9,34 +9,38 @@ import (
 	"testing"
 
 	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/users"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
-	"gorm.io/driver/sqlite"
-	"gorm.io/gorm"
 )
 
-// Define a mock database handler
-type MockDB struct {
-	mock.Mock
-	*gorm.DB
-}
+const testAuthSecret = "test-secret"
 
-func TestAddBook(t *testing.T) {
-	// Set up Gin router
-	r := gin.Default()
+// withUser stubs AuthMiddleware for tests that only care about ownership,
+// not token verification: it sets the authenticated user ID directly.
+func withUser(userID uint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(users.UserIDKey, userID)
+		c.Next()
+	}
+}
 
-	// Use SQLite in-memory database for testing
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+// newTestHandler builds a handler backed entirely by in-memory/filesystem
+// fakes, so tests don't need a real database or object store.
+func newTestHandler(t *testing.T) *handler {
+	store, err := NewFilesystemObjectStore(t.TempDir())
 	if err != nil {
-		t.Fatalf("failed to connect to the database: %v", err)
+		t.Fatalf("failed to create object store: %v", err)
 	}
+	return NewHandler(NewMemoryBookRepository(), NewMemoryAttachmentRepository(), store)
+}
 
-	// Auto migrate Book model
-	db.AutoMigrate(&models.Book{})
+func TestAddBook(t *testing.T) {
+	// Set up Gin router
+	r := gin.Default()
 
-	// Create handler
-	h := &handler{DB: db}
+	// Create handler against in-memory/filesystem fakes
+	h := newTestHandler(t)
 
 	// Register routes
 	r.POST("/books", h.AddBook)
@@ -66,16 +70,16 @@ func TestAddBook(t *testing.T) {
 
 	// Check the response body
 	var createdBook models.Book
-	err = json.Unmarshal(w.Body.Bytes(), &createdBook)
+	err := json.Unmarshal(w.Body.Bytes(), &createdBook)
 	assert.Nil(t, err)
 	assert.Equal(t, newBook.Title, createdBook.Title)
 	assert.Equal(t, newBook.Author, createdBook.Author)
 	assert.Equal(t, newBook.Description, createdBook.Description)
 
-	// Verify the book was saved in the database
-	var dbBook models.Book
-	if err := db.First(&dbBook, createdBook.ID).Error; err != nil {
-		t.Fatalf("book not found in database: %v", err)
+	// Verify the book was saved in the repository
+	dbBook, err := h.Repo.Get(createdBook.ID)
+	if err != nil {
+		t.Fatalf("book not found in repository: %v", err)
 	}
 	assert.Equal(t, newBook.Title, dbBook.Title)
 	assert.Equal(t, newBook.Author, dbBook.Author)
@@ -86,25 +90,15 @@ func TestDeleteBook(t *testing.T) {
 	// Set up Gin router
 	r := gin.Default()
 
-	// Use SQLite in-memory database for testing
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	if err != nil {
-		t.Fatalf("failed to connect to the database: %v", err)
-	}
-
-	// Auto migrate Book model
-	db.AutoMigrate(&models.Book{})
+	h := newTestHandler(t)
 
-	// Create a sample book in the database
+	// Create a sample book in the repository
 	book := models.Book{
 		Title:       "Test Book",
 		Author:      "Test Author",
 		Description: "Test Description",
 	}
-	db.Create(&book)
-
-	// Create handler
-	h := &handler{DB: db}
+	h.Repo.Create(&book)
 
 	// Register routes
 	r.DELETE("/books/:id", h.DeleteBook)
@@ -121,36 +115,24 @@ func TestDeleteBook(t *testing.T) {
 	// Check the status code
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	// Check the database to ensure the book is deleted
-	var deletedBook models.Book
-	result := db.First(&deletedBook, book.ID)
-	assert.Error(t, result.Error)
-	assert.Equal(t, gorm.ErrRecordNotFound, result.Error)
+	// Check the repository to ensure the book is deleted
+	_, err := h.Repo.Get(book.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
 }
 
 func TestGetBook(t *testing.T) {
 	// Set up Gin router
 	r := gin.Default()
 
-	// Use SQLite in-memory database for testing
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	if err != nil {
-		t.Fatalf("failed to connect to the database: %v", err)
-	}
-
-	// Auto migrate Book model
-	db.AutoMigrate(&models.Book{})
+	h := newTestHandler(t)
 
-	// Create a sample book in the database
+	// Create a sample book in the repository
 	book := models.Book{
 		Title:       "Test Book",
 		Author:      "Test Author",
 		Description: "Test Description",
 	}
-	db.Create(&book)
-
-	// Create handler
-	h := &handler{DB: db}
+	h.Repo.Create(&book)
 
 	// Register routes
 	r.GET("/books/:id", h.GetBook)
@@ -169,7 +151,7 @@ func TestGetBook(t *testing.T) {
 
 	// Check the response body
 	var fetchedBook models.Book
-	err = json.Unmarshal(w.Body.Bytes(), &fetchedBook)
+	err := json.Unmarshal(w.Body.Bytes(), &fetchedBook)
 	assert.Nil(t, err)
 	assert.Equal(t, book.ID, fetchedBook.ID)
 	assert.Equal(t, book.Title, fetchedBook.Title)
@@ -177,28 +159,29 @@ func TestGetBook(t *testing.T) {
 	assert.Equal(t, book.Description, fetchedBook.Description)
 }
 
+// getBooksResponse mirrors the JSON envelope returned by GET /books.
+type getBooksResponse struct {
+	Data       []models.Book `json:"data"`
+	Page       int           `json:"page"`
+	PageSize   int           `json:"page_size"`
+	Total      int64         `json:"total"`
+	TotalPages int           `json:"total_pages"`
+}
+
 func TestGetBooks(t *testing.T) {
 	// Set up Gin router
 	r := gin.Default()
 
-	// Use SQLite in-memory database for testing
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	if err != nil {
-		t.Fatalf("failed to connect to the database: %v", err)
-	}
-
-	// Auto migrate Book model
-	db.AutoMigrate(&models.Book{})
+	h := newTestHandler(t)
 
-	// Create some sample books in the database
+	// Create some sample books in the repository
 	books := []models.Book{
 		{Title: "Book 1", Author: "Author 1", Description: "Description 1"},
 		{Title: "Book 2", Author: "Author 2", Description: "Description 2"},
 	}
-	db.Create(&books)
-
-	// Create handler
-	h := &handler{DB: db}
+	for i := range books {
+		h.Repo.Create(&books[i])
+	}
 
 	// Register routes
 	r.GET("/books", h.GetBooks)
@@ -216,42 +199,89 @@ func TestGetBooks(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 
 	// Check the response body
-	var fetchedBooks []models.Book
-	err = json.Unmarshal(w.Body.Bytes(), &fetchedBooks)
+	var resp getBooksResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
 	assert.Nil(t, err)
-	assert.Equal(t, len(books), len(fetchedBooks))
+	assert.Equal(t, len(books), len(resp.Data))
+	assert.EqualValues(t, len(books), resp.Total)
+	assert.Equal(t, 1, resp.Page)
+	assert.Equal(t, 1, resp.TotalPages)
 
 	// Verify that each book matches the expected data
 	for i, book := range books {
-		assert.Equal(t, book.Title, fetchedBooks[i].Title)
-		assert.Equal(t, book.Author, fetchedBooks[i].Author)
-		assert.Equal(t, book.Description, fetchedBooks[i].Description)
+		assert.Equal(t, book.Title, resp.Data[i].Title)
+		assert.Equal(t, book.Author, resp.Data[i].Author)
+		assert.Equal(t, book.Description, resp.Data[i].Description)
 	}
 }
 
-func TestUpdateBook(t *testing.T) {
-	// Set up Gin router
+func TestGetBooksMultiPage(t *testing.T) {
 	r := gin.Default()
+	h := newTestHandler(t)
+	r.GET("/books", h.GetBooks)
 
-	// Use SQLite in-memory database for testing
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	if err != nil {
-		t.Fatalf("failed to connect to the database: %v", err)
+	for i := 1; i <= 5; i++ {
+		book := models.Book{Title: fmt.Sprintf("Book %d", i), Author: "Author"}
+		h.Repo.Create(&book)
 	}
 
-	// Auto migrate Book model
-	db.AutoMigrate(&models.Book{})
+	req, _ := http.NewRequest("GET", "/books?page=2&page_size=2&sort=title&order=asc", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
 
-	// Create a sample book in the database
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp getBooksResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Data, 2)
+	assert.EqualValues(t, 5, resp.Total)
+	assert.Equal(t, 3, resp.TotalPages)
+	assert.Equal(t, "Book 3", resp.Data[0].Title)
+	assert.Equal(t, "Book 4", resp.Data[1].Title)
+}
+
+func TestGetBooksEmptyPage(t *testing.T) {
+	r := gin.Default()
+	h := newTestHandler(t)
+	r.GET("/books", h.GetBooks)
+
+	req, _ := http.NewRequest("GET", "/books?page=5&page_size=10", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp getBooksResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Empty(t, resp.Data)
+	assert.EqualValues(t, 0, resp.Total)
+}
+
+func TestGetBooksInvalidSortKeyReturnsBadRequest(t *testing.T) {
+	r := gin.Default()
+	h := newTestHandler(t)
+	r.GET("/books", h.GetBooks)
+
+	req, _ := http.NewRequest("GET", "/books?sort=publisher", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateBook(t *testing.T) {
+	// Set up Gin router
+	r := gin.Default()
+
+	h := newTestHandler(t)
+
+	// Create a sample book in the repository
 	book := models.Book{
 		Title:       "Original Title",
 		Author:      "Original Author",
 		Description: "Original Description",
 	}
-	db.Create(&book)
-
-	// Create handler
-	h := &handler{DB: db}
+	h.Repo.Create(&book)
 
 	// Register routes
 	r.PUT("/books/:id", h.UpdateBook)
@@ -265,7 +295,6 @@ func TestUpdateBook(t *testing.T) {
 	body, _ := json.Marshal(updatedBook)
 
 	// Create a request to update the book
-	// req, _ := http.NewRequest("PUT", "/books/"+string(book.ID), bytes.NewBuffer(body))
 	req, _ := http.NewRequest("PUT", fmt.Sprintf("/books/%d", book.ID), bytes.NewBuffer(body))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -280,19 +309,125 @@ func TestUpdateBook(t *testing.T) {
 
 	// Check the response body
 	var fetchedBook models.Book
-	err = json.Unmarshal(w.Body.Bytes(), &fetchedBook)
+	err := json.Unmarshal(w.Body.Bytes(), &fetchedBook)
 	assert.Nil(t, err)
 	assert.Equal(t, book.ID, fetchedBook.ID)
 	assert.Equal(t, updatedBook.Title, fetchedBook.Title)
 	assert.Equal(t, updatedBook.Author, fetchedBook.Author)
 	assert.Equal(t, updatedBook.Description, fetchedBook.Description)
 
-	// Verify that the book was updated in the database
-	var dbBook models.Book
-	if err := db.First(&dbBook, book.ID).Error; err != nil {
-		t.Fatalf("book not found in database: %v", err)
+	// Verify that the book was updated in the repository
+	dbBook, err := h.Repo.Get(book.ID)
+	if err != nil {
+		t.Fatalf("book not found in repository: %v", err)
 	}
 	assert.Equal(t, updatedBook.Title, dbBook.Title)
 	assert.Equal(t, updatedBook.Author, dbBook.Author)
 	assert.Equal(t, updatedBook.Description, dbBook.Description)
 }
+
+func TestAddBookOwnsTheAuthenticatedUser(t *testing.T) {
+	r := gin.Default()
+	h := newTestHandler(t)
+	r.Use(withUser(7))
+	r.POST("/books", h.AddBook)
+
+	body, _ := json.Marshal(AddBookRequestBody{Title: "Title", Author: "Author"})
+	req, _ := http.NewRequest("POST", "/books", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var created models.Book
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.EqualValues(t, 7, created.UserID)
+}
+
+func TestGetBooksOnlyReturnsTheAuthenticatedUsersBooks(t *testing.T) {
+	r := gin.Default()
+	h := newTestHandler(t)
+	r.Use(withUser(1))
+	r.GET("/books", h.GetBooks)
+
+	h.Repo.Create(&models.Book{Title: "Mine", Author: "Author", UserID: 1})
+	h.Repo.Create(&models.Book{Title: "Theirs", Author: "Author", UserID: 2})
+
+	req, _ := http.NewRequest("GET", "/books", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp getBooksResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, "Mine", resp.Data[0].Title)
+}
+
+func TestGetBookOwnedByAnotherUserIsForbidden(t *testing.T) {
+	r := gin.Default()
+	h := newTestHandler(t)
+	r.Use(withUser(1))
+	r.GET("/books/:id", h.GetBook)
+
+	book := models.Book{Title: "Theirs", Author: "Author", UserID: 2}
+	h.Repo.Create(&book)
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/books/%d", book.ID), nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestUpdateBookOwnedByAnotherUserIsForbidden(t *testing.T) {
+	r := gin.Default()
+	h := newTestHandler(t)
+	r.Use(withUser(1))
+	r.PUT("/books/:id", h.UpdateBook)
+
+	book := models.Book{Title: "Theirs", Author: "Author", UserID: 2}
+	h.Repo.Create(&book)
+
+	body, _ := json.Marshal(UpdateBookRequestBody{Title: "Hijacked", Author: "Author"})
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/books/%d", book.ID), bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestDeleteBookOwnedByAnotherUserIsForbidden(t *testing.T) {
+	r := gin.Default()
+	h := newTestHandler(t)
+	r.Use(withUser(1))
+	r.DELETE("/books/:id", h.DeleteBook)
+
+	book := models.Book{Title: "Theirs", Author: "Author", UserID: 2}
+	h.Repo.Create(&book)
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/books/%d", book.ID), nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	_, err := h.Repo.Get(book.ID)
+	assert.NoError(t, err)
+}
+
+func TestGetBooksWithoutAuthTokenIsUnauthorized(t *testing.T) {
+	r := gin.Default()
+	h := newTestHandler(t)
+	r.Use(users.AuthMiddleware(testAuthSecret))
+	r.GET("/books", h.GetBooks)
+
+	req, _ := http.NewRequest("GET", "/books", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
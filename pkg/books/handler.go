@@ -0,0 +1,389 @@
+package books
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/users"
+	"github.com/gin-gonic/gin"
+)
+
+// handler serves the /books endpoints on top of a BookRepository, an
+// AttachmentRepository for file metadata, and an ObjectStore for the
+// underlying file bytes.
+type handler struct {
+	Repo        BookRepository
+	Attachments AttachmentRepository
+	Objects     ObjectStore
+}
+
+// NewHandler builds the books HTTP handler on top of the given repository,
+// attachment metadata store, and object store.
+func NewHandler(repo BookRepository, attachments AttachmentRepository, objects ObjectStore) *handler {
+	return &handler{Repo: repo, Attachments: attachments, Objects: objects}
+}
+
+// RegisterRoutes wires the books endpoints onto the given router group.
+func RegisterRoutes(rg gin.IRouter, h *handler) {
+	rg.POST("/books", h.AddBook)
+	rg.GET("/books", h.GetBooks)
+	rg.GET("/books/:id", h.GetBook)
+	rg.PUT("/books/:id", h.UpdateBook)
+	rg.DELETE("/books/:id", h.DeleteBook)
+	rg.POST("/books/:id/cover", h.UploadCover)
+	rg.GET("/books/:id/cover", h.GetCover)
+	rg.POST("/books/:id/attachments", h.UploadAttachment)
+}
+
+// AddBookRequestBody is the payload accepted by POST /books.
+type AddBookRequestBody struct {
+	Title       string `json:"title" binding:"required"`
+	Author      string `json:"author" binding:"required"`
+	Description string `json:"description"`
+}
+
+// UpdateBookRequestBody is the payload accepted by PUT /books/:id.
+type UpdateBookRequestBody struct {
+	Title       string `json:"title" binding:"required"`
+	Author      string `json:"author" binding:"required"`
+	Description string `json:"description"`
+}
+
+func (h *handler) AddBook(c *gin.Context) {
+	var body AddBookRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := users.CurrentUserID(c)
+	book := models.Book{
+		UserID:      userID,
+		Title:       body.Title,
+		Author:      body.Author,
+		Description: body.Description,
+	}
+	if err := h.Repo.Create(&book); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, book)
+}
+
+func (h *handler) GetBook(c *gin.Context) {
+	id, err := parseBookID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	book, err := h.Repo.Get(id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "book not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.authorizeBook(c, book) {
+		return
+	}
+
+	c.JSON(http.StatusOK, book)
+}
+
+func (h *handler) GetBooks(c *gin.Context) {
+	var query ListBooksQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query.Normalize()
+	if err := query.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query.UserID, _ = users.CurrentUserID(c)
+
+	books, total, err := h.Repo.List(query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":        books,
+		"page":        query.Page,
+		"page_size":   query.PageSize,
+		"total":       total,
+		"total_pages": query.TotalPages(total),
+	})
+}
+
+func (h *handler) UpdateBook(c *gin.Context) {
+	id, err := parseBookID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	existing, err := h.Repo.Get(id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "book not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.authorizeBook(c, existing) {
+		return
+	}
+
+	var body UpdateBookRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	existing.Title = body.Title
+	existing.Author = body.Author
+	existing.Description = body.Description
+
+	if err := h.Repo.Update(existing); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "book not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.Repo.Get(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+func (h *handler) DeleteBook(c *gin.Context) {
+	id, err := parseBookID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	book, err := h.Repo.Get(id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "book not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.authorizeBook(c, book) {
+		return
+	}
+
+	if err := h.deleteBookObjects(book); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.Repo.Delete(id); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "book not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "book deleted"})
+}
+
+// deleteBookObjects removes the cover image and all attachments belonging
+// to book from the object store and attachment repository, so DeleteBook
+// never leaves orphaned blobs behind.
+func (h *handler) deleteBookObjects(book *models.Book) error {
+	if book.CoverImageKey != "" {
+		if err := h.Objects.Delete(book.CoverImageKey); err != nil {
+			return err
+		}
+	}
+
+	attachments, err := h.Attachments.ListByBook(book.ID)
+	if err != nil {
+		return err
+	}
+	for _, att := range attachments {
+		if err := h.Objects.Delete(att.Key); err != nil {
+			return err
+		}
+	}
+
+	return h.Attachments.DeleteByBook(book.ID)
+}
+
+func (h *handler) UploadCover(c *gin.Context) {
+	id, err := parseBookID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	book, err := h.Repo.Get(id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "book not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.authorizeBook(c, book) {
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	defer file.Close()
+
+	contentType := header.Header.Get("Content-Type")
+	key := fmt.Sprintf("books/%d/cover", id)
+	if err := h.Objects.Put(key, file, header.Size, contentType); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	book.CoverImageKey = key
+	book.CoverImageType = contentType
+	if err := h.Repo.Update(book); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, book)
+}
+
+func (h *handler) GetCover(c *gin.Context) {
+	id, err := parseBookID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	book, err := h.Repo.Get(id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "book not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.authorizeBook(c, book) {
+		return
+	}
+	if book.CoverImageKey == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cover not set"})
+		return
+	}
+
+	object, err := h.Objects.Get(book.CoverImageKey)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "cover not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer object.Close()
+
+	c.Header("Content-Type", book.CoverImageType)
+	if _, err := io.Copy(c.Writer, object); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+}
+
+func (h *handler) UploadAttachment(c *gin.Context) {
+	id, err := parseBookID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	book, err := h.Repo.Get(id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "book not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.authorizeBook(c, book) {
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	defer file.Close()
+
+	contentType := header.Header.Get("Content-Type")
+	key := fmt.Sprintf("books/%d/attachments/%s", id, header.Filename)
+	if err := h.Objects.Put(key, file, header.Size, contentType); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	attachment := models.Attachment{
+		BookID:      id,
+		Key:         key,
+		Filename:    header.Filename,
+		ContentType: contentType,
+		Size:        header.Size,
+	}
+	if err := h.Attachments.Create(&attachment); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// authorizeBook reports whether the caller owns book, writing a 403 and
+// returning false otherwise.
+func (h *handler) authorizeBook(c *gin.Context, book *models.Book) bool {
+	userID, _ := users.CurrentUserID(c)
+	if book.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not your book"})
+		return false
+	}
+	return true
+}
+
+func parseBookID(c *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid book id")
+	}
+	return uint(id), nil
+}
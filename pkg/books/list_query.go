@@ -0,0 +1,78 @@
+package books
+
+import "fmt"
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// allowedBookSortFields maps the sort keys accepted over the wire to the
+// actual column names, so user input never reaches an ORDER BY clause
+// directly.
+var allowedBookSortFields = map[string]string{
+	"title":      "title",
+	"author":     "author",
+	"created_at": "created_at",
+}
+
+// ListBooksQuery is the typed, Gin-bound query for GET /books: pagination,
+// filtering by author/title, and sorting.
+type ListBooksQuery struct {
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+	Author   string `form:"author"`
+	Title    string `form:"title"`
+	Sort     string `form:"sort"`
+	Order    string `form:"order"`
+
+	// UserID restricts the listing to books owned by this user. It is set
+	// by the handler from the authenticated request, never bound from the
+	// query string.
+	UserID uint `form:"-"`
+}
+
+// Normalize fills in defaults and caps PageSize server-side. It never
+// fails; call Validate afterwards to reject unknown sort/order values.
+func (q *ListBooksQuery) Normalize() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PageSize < 1 {
+		q.PageSize = defaultPageSize
+	}
+	if q.PageSize > maxPageSize {
+		q.PageSize = maxPageSize
+	}
+	if q.Sort == "" {
+		q.Sort = "created_at"
+	}
+	if q.Order == "" {
+		q.Order = "asc"
+	}
+}
+
+// Validate rejects unknown sort keys and orders. Call it after Normalize.
+func (q ListBooksQuery) Validate() error {
+	if _, ok := allowedBookSortFields[q.Sort]; !ok {
+		return fmt.Errorf("invalid sort key %q", q.Sort)
+	}
+	if q.Order != "asc" && q.Order != "desc" {
+		return fmt.Errorf("invalid order %q", q.Order)
+	}
+	return nil
+}
+
+// Offset returns the SQL/slice offset for this page.
+func (q ListBooksQuery) Offset() int {
+	return (q.Page - 1) * q.PageSize
+}
+
+// TotalPages returns the page count for the given total row count.
+func (q ListBooksQuery) TotalPages(total int64) int {
+	if total == 0 {
+		return 1
+	}
+	pages := (total + int64(q.PageSize) - 1) / int64(q.PageSize)
+	return int(pages)
+}
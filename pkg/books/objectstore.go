@@ -0,0 +1,12 @@
+package books
+
+import "io"
+
+// ObjectStore abstracts binary blob storage for book covers and
+// attachments, so production code can point at an S3-compatible bucket
+// (MinIO) while tests use a local filesystem instead.
+type ObjectStore interface {
+	Put(key string, r io.Reader, size int64, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
@@ -0,0 +1,57 @@
+package books
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// filesystemObjectStore is an ObjectStore backed by a local directory, used
+// in tests and local development in place of MinIO.
+type filesystemObjectStore struct {
+	root string
+}
+
+// NewFilesystemObjectStore builds an ObjectStore rooted at dir, creating it
+// if it doesn't already exist.
+func NewFilesystemObjectStore(dir string) (ObjectStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &filesystemObjectStore{root: dir}, nil
+}
+
+func (s *filesystemObjectStore) Put(key string, r io.Reader, size int64, contentType string) error {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
+
+func (s *filesystemObjectStore) Get(key string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(s.root, filepath.FromSlash(key)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *filesystemObjectStore) Delete(key string) error {
+	err := os.Remove(filepath.Join(s.root, filepath.FromSlash(key)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package books
+
+import (
+	"context"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// minioObjectStore is the production ObjectStore, backed by a MinIO (or any
+// S3-compatible) bucket.
+type minioObjectStore struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewMinioObjectStore builds an ObjectStore against the given bucket. The
+// bucket must already exist.
+func NewMinioObjectStore(client *minio.Client, bucket string) ObjectStore {
+	return &minioObjectStore{client: client, bucket: bucket}
+}
+
+func (s *minioObjectStore) Put(key string, r io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(context.Background(), s.bucket, key, r, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+func (s *minioObjectStore) Get(key string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(context.Background(), s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := obj.Stat(); err != nil {
+		errResp := minio.ToErrorResponse(err)
+		if errResp.Code == "NoSuchKey" {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (s *minioObjectStore) Delete(key string) error {
+	return s.client.RemoveObject(context.Background(), s.bucket, key, minio.RemoveObjectOptions{})
+}
@@ -0,0 +1,25 @@
+package books
+
+import "github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+
+// BookRepository abstracts persistence for books so the handler can be
+// exercised against any storage backend (GORM, in-memory, ...).
+type BookRepository interface {
+	Create(book *models.Book) error
+	Get(id uint) (*models.Book, error)
+	// List returns the page of books matching query along with the total
+	// number of matching rows across all pages. When query.UserID is set,
+	// only that user's books are considered.
+	List(query ListBooksQuery) (books []models.Book, total int64, err error)
+	Update(book *models.Book) error
+	Delete(id uint) error
+	Count() (int64, error)
+}
+
+// ErrNotFound is returned by a BookRepository when the requested book does
+// not exist, regardless of backend.
+var ErrNotFound = errNotFound{}
+
+type errNotFound struct{}
+
+func (errNotFound) Error() string { return "book not found" }
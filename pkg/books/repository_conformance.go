@@ -0,0 +1,172 @@
+package books
+
+import (
+	"testing"
+
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// RepositoryFactory builds a fresh, empty BookRepository for a single test.
+// Implementations should return an isolated backend (e.g. a new in-memory
+// SQLite file, or a new map) so tests can run in any order.
+type RepositoryFactory func(t *testing.T) BookRepository
+
+// RepositoryConformanceSuite runs the same set of behavioral assertions
+// against any BookRepository implementation, so new backends (Postgres,
+// memory, ...) only need to be wired into `factory` to be verified.
+func RepositoryConformanceSuite(t *testing.T, factory RepositoryFactory) {
+	t.Run("CreateAssignsAutoIncrementIDs", func(t *testing.T) {
+		repo := factory(t)
+
+		first := models.Book{Title: "First", Author: "Author"}
+		second := models.Book{Title: "Second", Author: "Author"}
+		assert.NoError(t, repo.Create(&first))
+		assert.NoError(t, repo.Create(&second))
+
+		assert.NotZero(t, first.ID)
+		assert.NotEqual(t, first.ID, second.ID)
+	})
+
+	t.Run("GetMissingReturnsNotFound", func(t *testing.T) {
+		repo := factory(t)
+
+		_, err := repo.Get(999)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("ListReturnsAllCreatedBooks", func(t *testing.T) {
+		repo := factory(t)
+
+		assert.NoError(t, repo.Create(&models.Book{Title: "Book 1", Author: "Author 1"}))
+		assert.NoError(t, repo.Create(&models.Book{Title: "Book 2", Author: "Author 2"}))
+
+		books, total, err := repo.List(defaultListQuery())
+		assert.NoError(t, err)
+		assert.Len(t, books, 2)
+		assert.EqualValues(t, 2, total)
+
+		count, err := repo.Count()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+	})
+
+	t.Run("ListPaginatesFiltersAndSorts", func(t *testing.T) {
+		repo := factory(t)
+
+		assert.NoError(t, repo.Create(&models.Book{Title: "Charlie", Author: "Zed"}))
+		assert.NoError(t, repo.Create(&models.Book{Title: "Alpha", Author: "Yara"}))
+		assert.NoError(t, repo.Create(&models.Book{Title: "Bravo", Author: "Zed"}))
+
+		q := defaultListQuery()
+		q.PageSize = 2
+		q.Sort = "title"
+		q.Order = "asc"
+
+		page1, total, err := repo.List(q)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 3, total)
+		assert.Len(t, page1, 2)
+		assert.Equal(t, "Alpha", page1[0].Title)
+		assert.Equal(t, "Bravo", page1[1].Title)
+
+		q.Page = 2
+		page2, _, err := repo.List(q)
+		assert.NoError(t, err)
+		assert.Len(t, page2, 1)
+		assert.Equal(t, "Charlie", page2[0].Title)
+
+		filtered := defaultListQuery()
+		filtered.Author = "Zed"
+		zedBooks, total, err := repo.List(filtered)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, total)
+		assert.Len(t, zedBooks, 2)
+	})
+
+	t.Run("ListFiltersByUserID", func(t *testing.T) {
+		repo := factory(t)
+
+		assert.NoError(t, repo.Create(&models.Book{Title: "Mine", Author: "Author", UserID: 1}))
+		assert.NoError(t, repo.Create(&models.Book{Title: "Theirs", Author: "Author", UserID: 2}))
+
+		q := defaultListQuery()
+		q.UserID = 1
+		books, total, err := repo.List(q)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, total)
+		assert.Len(t, books, 1)
+		assert.Equal(t, "Mine", books[0].Title)
+	})
+
+	t.Run("DuplicateTitleAndAuthorAreBothAllowed", func(t *testing.T) {
+		repo := factory(t)
+
+		assert.NoError(t, repo.Create(&models.Book{Title: "Same", Author: "Same"}))
+		assert.NoError(t, repo.Create(&models.Book{Title: "Same", Author: "Same"}))
+
+		count, err := repo.Count()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+	})
+
+	t.Run("UpdateOfMissingRecordReturnsNotFound", func(t *testing.T) {
+		repo := factory(t)
+
+		err := repo.Update(&models.Book{Title: "Ghost"})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("UpdatePersistsChanges", func(t *testing.T) {
+		repo := factory(t)
+
+		book := models.Book{Title: "Original", Author: "Original"}
+		assert.NoError(t, repo.Create(&book))
+
+		book.Title = "Updated"
+		assert.NoError(t, repo.Update(&book))
+
+		fetched, err := repo.Get(book.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Updated", fetched.Title)
+	})
+
+	t.Run("UpdateClearsAFieldToItsZeroValue", func(t *testing.T) {
+		repo := factory(t)
+
+		book := models.Book{Title: "Original", Author: "Original", Description: "Has a description"}
+		assert.NoError(t, repo.Create(&book))
+
+		book.Description = ""
+		assert.NoError(t, repo.Update(&book))
+
+		fetched, err := repo.Get(book.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, fetched.Description)
+	})
+
+	t.Run("DeleteOfMissingRecordReturnsNotFound", func(t *testing.T) {
+		repo := factory(t)
+
+		assert.ErrorIs(t, repo.Delete(999), ErrNotFound)
+	})
+
+	t.Run("DeleteRemovesTheRecord", func(t *testing.T) {
+		repo := factory(t)
+
+		book := models.Book{Title: "Temporary", Author: "Author"}
+		assert.NoError(t, repo.Create(&book))
+		assert.NoError(t, repo.Delete(book.ID))
+
+		_, err := repo.Get(book.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+// defaultListQuery returns a normalized, validated ListBooksQuery, mirroring
+// what the handler would produce for a request with no query parameters.
+func defaultListQuery() ListBooksQuery {
+	q := ListBooksQuery{}
+	q.Normalize()
+	return q
+}
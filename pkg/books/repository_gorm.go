@@ -0,0 +1,100 @@
+package books
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+	"gorm.io/gorm"
+)
+
+// gormBookRepository is the production BookRepository backed by GORM.
+type gormBookRepository struct {
+	db *gorm.DB
+}
+
+// NewGormBookRepository builds a BookRepository backed by the given GORM
+// connection. The caller is responsible for migrating models.Book.
+func NewGormBookRepository(db *gorm.DB) BookRepository {
+	return &gormBookRepository{db: db}
+}
+
+func (r *gormBookRepository) Create(book *models.Book) error {
+	return r.db.Create(book).Error
+}
+
+func (r *gormBookRepository) Get(id uint) (*models.Book, error) {
+	var book models.Book
+	if err := r.db.First(&book, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &book, nil
+}
+
+func (r *gormBookRepository) List(q ListBooksQuery) ([]models.Book, int64, error) {
+	query := r.db.Model(&models.Book{})
+	if q.UserID != 0 {
+		query = query.Where("user_id = ?", q.UserID)
+	}
+	if q.Author != "" {
+		query = query.Where("author LIKE ?", "%"+q.Author+"%")
+	}
+	if q.Title != "" {
+		query = query.Where("title LIKE ?", "%"+q.Title+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var books []models.Book
+	err := query.
+		Order(fmt.Sprintf("%s %s", allowedBookSortFields[q.Sort], q.Order)).
+		Offset(q.Offset()).
+		Limit(q.PageSize).
+		Find(&books).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return books, total, nil
+}
+
+func (r *gormBookRepository) Update(book *models.Book) error {
+	// Select forces these columns through even when the caller is clearing
+	// one to its zero value; Updates(book) alone skips zero-value fields on
+	// a struct, silently dropping the change.
+	result := r.db.Model(&models.Book{}).
+		Where("id = ?", book.ID).
+		Select("title", "author", "description", "cover_image_key", "cover_image_type").
+		Updates(book)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormBookRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Book{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormBookRepository) Count() (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.Book{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
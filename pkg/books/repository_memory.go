@@ -0,0 +1,136 @@
+package books
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+)
+
+// memoryBookRepository is a BookRepository backed by a plain map, intended
+// for tests and local development where a real database isn't available.
+type memoryBookRepository struct {
+	mu     sync.Mutex
+	books  map[uint]models.Book
+	nextID uint
+}
+
+// NewMemoryBookRepository builds an in-memory BookRepository with
+// auto-incrementing IDs, mirroring the semantics of the GORM backend.
+func NewMemoryBookRepository() BookRepository {
+	return &memoryBookRepository{
+		books:  make(map[uint]models.Book),
+		nextID: 1,
+	}
+}
+
+func (r *memoryBookRepository) Create(book *models.Book) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	book.ID = r.nextID
+	r.nextID++
+	book.CreatedAt = time.Now()
+	r.books[book.ID] = *book
+	return nil
+}
+
+func (r *memoryBookRepository) Get(id uint) (*models.Book, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	book, ok := r.books[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &book, nil
+}
+
+func (r *memoryBookRepository) List(q ListBooksQuery) ([]models.Book, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched := make([]models.Book, 0, len(r.books))
+	for _, book := range r.books {
+		if q.UserID != 0 && book.UserID != q.UserID {
+			continue
+		}
+		if q.Author != "" && !strings.Contains(strings.ToLower(book.Author), strings.ToLower(q.Author)) {
+			continue
+		}
+		if q.Title != "" && !strings.Contains(strings.ToLower(book.Title), strings.ToLower(q.Title)) {
+			continue
+		}
+		matched = append(matched, book)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		less := lessBook(matched[i], matched[j], q.Sort)
+		if q.Order == "desc" {
+			return !less
+		}
+		return less
+	})
+
+	total := int64(len(matched))
+
+	start := q.Offset()
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + q.PageSize
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	return matched[start:end], total, nil
+}
+
+func lessBook(a, b models.Book, sortKey string) bool {
+	switch sortKey {
+	case "title":
+		return a.Title < b.Title
+	case "author":
+		return a.Author < b.Author
+	default:
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+}
+
+func (r *memoryBookRepository) Update(book *models.Book) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.books[book.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	updated := existing
+	updated.Title = book.Title
+	updated.Author = book.Author
+	updated.Description = book.Description
+	updated.CoverImageKey = book.CoverImageKey
+	updated.CoverImageType = book.CoverImageType
+	r.books[book.ID] = updated
+	return nil
+}
+
+func (r *memoryBookRepository) Delete(id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.books[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.books, id)
+	return nil
+}
+
+func (r *memoryBookRepository) Count() (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return int64(len(r.books)), nil
+}
@@ -0,0 +1,28 @@
+package books
+
+import (
+	"testing"
+
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGormBookRepository(t *testing.T) {
+	RepositoryConformanceSuite(t, func(t *testing.T) BookRepository {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("failed to connect to the database: %v", err)
+		}
+		if err := db.AutoMigrate(&models.Book{}); err != nil {
+			t.Fatalf("failed to migrate: %v", err)
+		}
+		return NewGormBookRepository(db)
+	})
+}
+
+func TestMemoryBookRepository(t *testing.T) {
+	RepositoryConformanceSuite(t, func(t *testing.T) BookRepository {
+		return NewMemoryBookRepository()
+	})
+}
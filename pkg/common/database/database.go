@@ -0,0 +1,26 @@
+package database
+
+import (
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Connect opens the application's GORM connection and migrates the known
+// models. dsn is passed straight to the SQLite driver (e.g. "app.db" or
+// ":memory:").
+func Connect(dsn string) (*gorm.DB, error) {
+	// TranslateError lets repositories match driver-specific errors (e.g. a
+	// unique constraint violation) against GORM's portable sentinels such
+	// as gorm.ErrDuplicatedKey.
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{TranslateError: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.AutoMigrate(&models.User{}, &models.Book{}, &models.Attachment{}); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,199 @@
+// Package accesslog provides a Gin middleware that emits one access-log
+// line per request, in Apache combined-log-format style, inspired by the
+// go-json-rest access log module.
+package accesslog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultFormat is Apache's combined log format, with %D (request duration
+// in microseconds) appended.
+const DefaultFormat = `%h %l %u %t "%r" %>s %b %D`
+
+// Config controls New's output.
+type Config struct {
+	// Writer is where rendered log lines are written. Defaults to
+	// gin.DefaultWriter if nil.
+	Writer io.Writer
+
+	// Format is the Apache-style format string to render. Ignored when
+	// JSON is true. Defaults to DefaultFormat.
+	Format string
+
+	// JSON switches to one-JSON-object-per-line output, for ingestion by
+	// log aggregators that don't parse Apache-style log lines.
+	JSON bool
+
+	// RemoteUser extracts the authenticated principal for the %u directive
+	// and the JSON "user" field. Defaults to always returning "-".
+	RemoteUser func(c *gin.Context) string
+}
+
+// New builds a Gin middleware that logs one access-log line per request to
+// cfg.Writer.
+func New(cfg Config) gin.HandlerFunc {
+	writer := cfg.Writer
+	if writer == nil {
+		writer = gin.DefaultWriter
+	}
+	format := cfg.Format
+	if format == "" {
+		format = DefaultFormat
+	}
+	remoteUser := cfg.RemoteUser
+	if remoteUser == nil {
+		remoteUser = func(*gin.Context) string { return "-" }
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		defer func() {
+			// Recover here so a panicking handler still gets its 500
+			// logged: this middleware's defer runs before an outer
+			// gin.Recovery() has written the response, so without this,
+			// c.Writer.Status() below would still read whatever it was
+			// pre-panic (e.g. 200). Re-panic afterwards so Recovery still
+			// handles the actual response.
+			rec := recover()
+
+			e := newEntry(c, start, remoteUser(c))
+			if rec != nil {
+				e.Status = http.StatusInternalServerError
+			}
+
+			if cfg.JSON {
+				if data, err := json.Marshal(e.toJSON()); err == nil {
+					fmt.Fprintln(writer, string(data))
+				}
+			} else {
+				fmt.Fprintln(writer, e.render(format))
+			}
+
+			if rec != nil {
+				panic(rec)
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+// entry is the set of fields a single request can be rendered from, either
+// as an Apache-style log line or as JSON.
+type entry struct {
+	RemoteHost     string
+	RemoteUser     string
+	Time           time.Time
+	Method         string
+	Path           string
+	Proto          string
+	Status         int
+	Size           int
+	DurationMicros int64
+}
+
+func newEntry(c *gin.Context, start time.Time, remoteUser string) *entry {
+	size := c.Writer.Size()
+	if size < 0 {
+		size = 0
+	}
+	return &entry{
+		RemoteHost:     c.ClientIP(),
+		RemoteUser:     remoteUser,
+		Time:           start,
+		Method:         c.Request.Method,
+		Path:           c.Request.URL.RequestURI(),
+		Proto:          c.Request.Proto,
+		Status:         c.Writer.Status(),
+		Size:           size,
+		DurationMicros: time.Since(start).Microseconds(),
+	}
+}
+
+// render expands format's Apache-style directives against e. Unknown
+// directives are copied through verbatim (with their leading %) rather than
+// rejected, since a bad format string is a configuration bug, not a
+// per-request failure.
+func (e *entry) render(format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			b.WriteByte(format[i])
+			continue
+		}
+		i++
+		angle := format[i] == '>'
+		if angle {
+			i++
+			if i >= len(format) {
+				b.WriteString("%>")
+				break
+			}
+		}
+		switch format[i] {
+		case 'h':
+			b.WriteString(e.RemoteHost)
+		case 'l':
+			b.WriteString("-")
+		case 'u':
+			b.WriteString(e.RemoteUser)
+		case 't':
+			b.WriteString("[" + e.Time.Format("02/Jan/2006:15:04:05 -0700") + "]")
+		case 'r':
+			b.WriteString(fmt.Sprintf("%s %s %s", e.Method, e.Path, e.Proto))
+		case 's':
+			b.WriteString(strconv.Itoa(e.Status))
+		case 'b':
+			if e.Size == 0 {
+				b.WriteString("-")
+			} else {
+				b.WriteString(strconv.Itoa(e.Size))
+			}
+		case 'D':
+			b.WriteString(strconv.FormatInt(e.DurationMicros, 10))
+		default:
+			b.WriteByte('%')
+			if angle {
+				b.WriteByte('>')
+			}
+			b.WriteByte(format[i])
+		}
+	}
+	return b.String()
+}
+
+// jsonEntry is the wire shape emitted in JSON mode.
+type jsonEntry struct {
+	Host       string `json:"host"`
+	User       string `json:"user"`
+	Time       string `json:"time"`
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Proto      string `json:"proto"`
+	Status     int    `json:"status"`
+	Bytes      int    `json:"bytes"`
+	DurationUs int64  `json:"duration_us"`
+}
+
+func (e *entry) toJSON() jsonEntry {
+	return jsonEntry{
+		Host:       e.RemoteHost,
+		User:       e.RemoteUser,
+		Time:       e.Time.Format(time.RFC3339),
+		Method:     e.Method,
+		Path:       e.Path,
+		Proto:      e.Proto,
+		Status:     e.Status,
+		Bytes:      e.Size,
+		DurationUs: e.DurationMicros,
+	}
+}
@@ -0,0 +1,119 @@
+package accesslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRouter(cfg Config) (*gin.Engine, *bytes.Buffer) {
+	var buf bytes.Buffer
+	cfg.Writer = &buf
+
+	r := gin.New()
+	r.Use(New(cfg))
+	r.GET("/books", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"data": []int{}})
+	})
+	return r, &buf
+}
+
+// newGetBooksRequest builds a GET /books request with RemoteAddr populated,
+// the way a real net/http server would set it before c.ClientIP() reads it;
+// http.NewRequest alone leaves it empty.
+func newGetBooksRequest() *http.Request {
+	req, _ := http.NewRequest("GET", "/books", nil)
+	req.RemoteAddr = "203.0.113.1:12345"
+	return req
+}
+
+func TestDefaultFormatMatchesApacheCombinedLogLine(t *testing.T) {
+	r, buf := newTestRouter(Config{})
+
+	req := newGetBooksRequest()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	line := buf.String()
+	assert.Regexp(t, regexp.MustCompile(`^\S+ - - \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] "GET /books HTTP/1\.1" 200 \d+ \d+\s*$`), line)
+}
+
+func TestRemoteUserDirectiveUsesConfiguredExtractor(t *testing.T) {
+	r, buf := newTestRouter(Config{
+		RemoteUser: func(c *gin.Context) string { return "42" },
+	})
+
+	req := newGetBooksRequest()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Regexp(t, regexp.MustCompile(`^\S+ - 42 \[`), buf.String())
+}
+
+func TestEachFormatDirectiveRendersIndependently(t *testing.T) {
+	cases := map[string]*regexp.Regexp{
+		"%h":  regexp.MustCompile(`^\S+$`),
+		"%l":  regexp.MustCompile(`^-$`),
+		"%u":  regexp.MustCompile(`^-$`),
+		"%t":  regexp.MustCompile(`^\[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\]$`),
+		"%r":  regexp.MustCompile(`^GET /books HTTP/1\.1$`),
+		"%>s": regexp.MustCompile(`^200$`),
+		"%b":  regexp.MustCompile(`^\d+$`),
+		"%D":  regexp.MustCompile(`^\d+$`),
+	}
+
+	for format, want := range cases {
+		r, buf := newTestRouter(Config{Format: format})
+
+		req := newGetBooksRequest()
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Regexp(t, want, buf.String()[:len(buf.String())-1], "format %q", format)
+	}
+}
+
+func TestJSONModeEmitsOneValidJSONObjectPerLine(t *testing.T) {
+	r, buf := newTestRouter(Config{
+		JSON:       true,
+		RemoteUser: func(c *gin.Context) string { return "7" },
+	})
+
+	req := newGetBooksRequest()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var line jsonEntry
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "7", line.User)
+	assert.Equal(t, "GET", line.Method)
+	assert.Equal(t, "/books", line.Path)
+	assert.Equal(t, http.StatusOK, line.Status)
+}
+
+func TestPanickingHandlerIsLoggedAs500(t *testing.T) {
+	var buf bytes.Buffer
+
+	r := gin.New()
+	// Mirrors main.go's order: accesslog is nested inside gin.Recovery(),
+	// so its own recover() is what must catch the panic first.
+	r.Use(gin.Recovery())
+	r.Use(New(Config{Writer: &buf}))
+	r.GET("/boom", func(c *gin.Context) {
+		panic("kaboom")
+	})
+
+	req, _ := http.NewRequest("GET", "/boom", nil)
+	req.RemoteAddr = "203.0.113.1:12345"
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Regexp(t, regexp.MustCompile(`"GET /boom HTTP/1\.1" 500 `), buf.String())
+}
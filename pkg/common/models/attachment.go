@@ -0,0 +1,14 @@
+package models
+
+import "gorm.io/gorm"
+
+// Attachment is a file associated with a Book. The file bytes live in an
+// object store; only the key and content metadata are persisted here.
+type Attachment struct {
+	gorm.Model
+	BookID      uint   `json:"book_id"`
+	Key         string `json:"key"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
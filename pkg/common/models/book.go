@@ -0,0 +1,18 @@
+package models
+
+import "gorm.io/gorm"
+
+// Book represents a single entry in the library, owned by the user who
+// created it.
+type Book struct {
+	gorm.Model
+	UserID      uint   `json:"user_id"`
+	Title       string `json:"title"`
+	Author      string `json:"author"`
+	Description string `json:"description"`
+
+	// CoverImageKey is the object key of the book's cover image in the
+	// configured ObjectStore, empty if no cover has been uploaded.
+	CoverImageKey  string `json:"cover_image_key,omitempty"`
+	CoverImageType string `json:"cover_image_type,omitempty"`
+}
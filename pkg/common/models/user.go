@@ -0,0 +1,10 @@
+package models
+
+import "gorm.io/gorm"
+
+// User is an account that owns books.
+type User struct {
+	gorm.Model
+	Email        string `json:"email" gorm:"uniqueIndex"`
+	PasswordHash string `json:"-"`
+}
@@ -0,0 +1,102 @@
+package users
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// handler serves /register and /login on top of a UserRepository.
+type handler struct {
+	Repo   UserRepository
+	Secret string
+}
+
+// NewHandler builds the users HTTP handler. secret signs and verifies the
+// JWTs issued by Login.
+func NewHandler(repo UserRepository, secret string) *handler {
+	return &handler{Repo: repo, Secret: secret}
+}
+
+// RegisterRoutes wires /register and /login onto the given router group.
+func RegisterRoutes(rg gin.IRouter, h *handler) {
+	rg.POST("/register", h.Register)
+	rg.POST("/login", h.Login)
+}
+
+// RegisterRequestBody is the payload accepted by POST /register.
+type RegisterRequestBody struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// LoginRequestBody is the payload accepted by POST /login.
+type LoginRequestBody struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// LoginResponse is returned by POST /login on success.
+type LoginResponse struct {
+	Token string `json:"token"`
+}
+
+func (h *handler) Register(c *gin.Context) {
+	var body RegisterRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(body.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	user := models.User{Email: body.Email, PasswordHash: string(hash)}
+	if err := h.Repo.Create(&user); err != nil {
+		if errors.Is(err, ErrEmailTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": "email already registered"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+func (h *handler) Login(c *gin.Context) {
+	var body LoginRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.Repo.GetByEmail(body.Email)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(body.Password)); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		return
+	}
+
+	token, err := generateToken(h.Secret, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, LoginResponse{Token: token})
+}
@@ -0,0 +1,65 @@
+package users
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "test-secret"
+
+func newTestRouter() (*gin.Engine, *handler) {
+	r := gin.Default()
+	h := NewHandler(NewMemoryUserRepository(), testSecret)
+	RegisterRoutes(r, h)
+	return r, h
+}
+
+func doJSON(r *gin.Engine, method, path string, body any) *httptest.ResponseRecorder {
+	b, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, path, bytes.NewBuffer(b))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRegisterThenLoginIssuesToken(t *testing.T) {
+	r, _ := newTestRouter()
+
+	registerW := doJSON(r, "POST", "/register", RegisterRequestBody{Email: "a@example.com", Password: "password123"})
+	assert.Equal(t, http.StatusCreated, registerW.Code)
+
+	loginW := doJSON(r, "POST", "/login", LoginRequestBody{Email: "a@example.com", Password: "password123"})
+	assert.Equal(t, http.StatusOK, loginW.Code)
+
+	var resp LoginResponse
+	assert.Nil(t, json.Unmarshal(loginW.Body.Bytes(), &resp))
+	assert.NotEmpty(t, resp.Token)
+
+	userID, err := parseToken(testSecret, resp.Token)
+	assert.Nil(t, err)
+	assert.NotZero(t, userID)
+}
+
+func TestLoginWithWrongPasswordIsRejected(t *testing.T) {
+	r, _ := newTestRouter()
+
+	doJSON(r, "POST", "/register", RegisterRequestBody{Email: "a@example.com", Password: "password123"})
+
+	loginW := doJSON(r, "POST", "/login", LoginRequestBody{Email: "a@example.com", Password: "wrong-password"})
+	assert.Equal(t, http.StatusUnauthorized, loginW.Code)
+}
+
+func TestRegisterDuplicateEmailIsRejected(t *testing.T) {
+	r, _ := newTestRouter()
+
+	doJSON(r, "POST", "/register", RegisterRequestBody{Email: "a@example.com", Password: "password123"})
+	secondW := doJSON(r, "POST", "/register", RegisterRequestBody{Email: "a@example.com", Password: "password123"})
+	assert.Equal(t, http.StatusConflict, secondW.Code)
+}
@@ -0,0 +1,54 @@
+package users
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenTTL is how long an issued access token remains valid.
+const tokenTTL = 24 * time.Hour
+
+// claims is the JWT payload issued on login; Subject holds the user ID.
+type claims struct {
+	jwt.RegisteredClaims
+}
+
+// generateToken issues a signed JWT for userID.
+func generateToken(secret string, userID uint) (string, error) {
+	c := claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(uint64(userID), 10),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, c)
+	return token.SignedString([]byte(secret))
+}
+
+// parseToken validates tokenString and returns the user ID it was issued
+// for.
+func parseToken(secret, tokenString string) (uint, error) {
+	var c claims
+	token, err := jwt.ParseWithClaims(tokenString, &c, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !token.Valid {
+		return 0, errors.New("invalid token")
+	}
+
+	id, err := strconv.ParseUint(c.Subject, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid token subject")
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,45 @@
+package users
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserIDKey is the gin.Context key AuthMiddleware stores the authenticated
+// user's ID under.
+const UserIDKey = "user_id"
+
+// AuthMiddleware validates the bearer JWT on every request, aborting with
+// 401 if it's missing or invalid, and otherwise stores the user ID under
+// UserIDKey for downstream handlers.
+func AuthMiddleware(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
+			return
+		}
+
+		userID, err := parseToken(secret, token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+
+		c.Set(UserIDKey, userID)
+		c.Next()
+	}
+}
+
+// CurrentUserID returns the authenticated user ID set by AuthMiddleware.
+func CurrentUserID(c *gin.Context) (uint, bool) {
+	v, ok := c.Get(UserIDKey)
+	if !ok {
+		return 0, false
+	}
+	id, ok := v.(uint)
+	return id, ok
+}
@@ -0,0 +1,25 @@
+package users
+
+import "github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+
+// UserRepository abstracts persistence for user accounts.
+type UserRepository interface {
+	Create(user *models.User) error
+	GetByEmail(email string) (*models.User, error)
+	GetByID(id uint) (*models.User, error)
+}
+
+// ErrNotFound is returned by a UserRepository when the requested user does
+// not exist, regardless of backend.
+var ErrNotFound = errNotFound{}
+
+type errNotFound struct{}
+
+func (errNotFound) Error() string { return "user not found" }
+
+// ErrEmailTaken is returned by Create when the email is already registered.
+var ErrEmailTaken = errEmailTaken{}
+
+type errEmailTaken struct{}
+
+func (errEmailTaken) Error() string { return "email already registered" }
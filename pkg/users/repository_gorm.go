@@ -0,0 +1,51 @@
+package users
+
+import (
+	"errors"
+
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+	"gorm.io/gorm"
+)
+
+// gormUserRepository is the production UserRepository, backed by GORM.
+type gormUserRepository struct {
+	db *gorm.DB
+}
+
+// NewGormUserRepository builds a UserRepository backed by the given GORM
+// connection. The caller is responsible for migrating models.User.
+func NewGormUserRepository(db *gorm.DB) UserRepository {
+	return &gormUserRepository{db: db}
+}
+
+func (r *gormUserRepository) Create(user *models.User) error {
+	if err := r.db.Create(user).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrEmailTaken
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *gormUserRepository) GetByEmail(email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) GetByID(id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
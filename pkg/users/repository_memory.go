@@ -0,0 +1,62 @@
+package users
+
+import (
+	"sync"
+
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+)
+
+// memoryUserRepository is a UserRepository backed by a plain map, intended
+// for tests and local development.
+type memoryUserRepository struct {
+	mu     sync.Mutex
+	users  map[uint]models.User
+	nextID uint
+}
+
+// NewMemoryUserRepository builds an in-memory UserRepository.
+func NewMemoryUserRepository() UserRepository {
+	return &memoryUserRepository{
+		users:  make(map[uint]models.User),
+		nextID: 1,
+	}
+}
+
+func (r *memoryUserRepository) Create(user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if existing.Email == user.Email {
+			return ErrEmailTaken
+		}
+	}
+
+	user.ID = r.nextID
+	r.nextID++
+	r.users[user.ID] = *user
+	return nil
+}
+
+func (r *memoryUserRepository) GetByEmail(email string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range r.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryUserRepository) GetByID(id uint) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &user, nil
+}
@@ -0,0 +1,31 @@
+package users
+
+import (
+	"testing"
+
+	"github.com/Frankkwizera/go-gin-api-medium/pkg/common/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newTestGormUserRepository(t *testing.T) UserRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("failed to connect to the database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.User{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return NewGormUserRepository(db)
+}
+
+func TestGormUserRepositoryDuplicateEmailReturnsErrEmailTaken(t *testing.T) {
+	repo := newTestGormUserRepository(t)
+
+	first := models.User{Email: "a@example.com", PasswordHash: "hash"}
+	assert.NoError(t, repo.Create(&first))
+
+	second := models.User{Email: "a@example.com", PasswordHash: "hash"}
+	assert.ErrorIs(t, repo.Create(&second), ErrEmailTaken)
+}